@@ -0,0 +1,233 @@
+// Command mimetype detects the MIME type of stdin or of one or more files
+// and prints the result in a form that's easy to consume from a shell
+// script.
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/YinTokey/mimetype"
+)
+
+// headerLen is how many leading bytes are read from stdin or a file
+// before handing them to mimetype.Detect. It matches the library's own
+// default detection window.
+const headerLen = 3072
+
+var (
+	flagExtension = flag.Bool("e", false, "also print the detected file extension")
+	flagMIMEOnly  = flag.Bool("mime-only", false, "print only the MIME type, without the file name")
+	flagJSON      = flag.Bool("json", false, "print one JSON object per input instead of plain text")
+	flagParents   = flag.Bool("parents", false, "also print the ancestor MIME types, most specific first")
+	flagHint      = flag.String("mime", "", "skip detection and use this MIME type instead; useful when the caller already knows the format of a non-seekable stream")
+	flagHintFrom  = flag.String("from", "", "alias for -mime")
+	flagExec      = flag.String("exec", "", `comma-separated pattern=command pairs, e.g. "image/*=feh -,audio/*=mpv -"; runs the first command whose pattern matches the detected type, replaying the sniffed header on its stdin`)
+)
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "usage: %s [flags] [file...]\n\nDetects the MIME type of stdin, or of one or more files. Exits 0 when\na specific type was matched, 1 when detection fell back to\napplication/octet-stream, and 2 on a usage or I/O error.\n\n", os.Args[0])
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+
+	paths := flag.Args()
+	if len(paths) == 0 {
+		paths = []string{"-"}
+	}
+
+	matched, fellBack, hadError := false, false, false
+	for _, path := range paths {
+		ok, err := detectOne(path)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "mimetype: %s: %v\n", path, err)
+			hadError = true
+			continue
+		}
+		if ok {
+			matched = true
+		} else {
+			fellBack = true
+		}
+	}
+
+	switch {
+	case hadError:
+		os.Exit(2)
+	case fellBack && !matched:
+		os.Exit(1)
+	default:
+		os.Exit(0)
+	}
+}
+
+// hintMIME returns the MIME type given via -mime or its -from alias, or ""
+// if neither was set.
+func hintMIME() string {
+	if *flagHint != "" {
+		return *flagHint
+	}
+
+	return *flagHintFrom
+}
+
+// detectOne detects the type of the file at path ("-" means stdin) and
+// either prints it or, with -exec, dispatches it to a matching command.
+// It reports whether detection matched a specific MIME type as opposed to
+// falling back to application/octet-stream.
+func detectOne(path string) (matched bool, err error) {
+	r, closer, err := openInput(path)
+	if err != nil {
+		return false, err
+	}
+	if closer != nil {
+		defer closer.Close()
+	}
+
+	var m *mimetype.MIME
+	var header []byte
+	mime := hintMIME()
+	if mime == "" {
+		// Only block on filling the sniffing window when we actually need
+		// to detect; a caller that already knows the format (e.g. because
+		// it's feeding a slow, non-seekable pipe) shouldn't have to wait
+		// for it.
+		var rest io.Reader
+		rest, header, err = readHeader(r)
+		if err != nil {
+			return false, err
+		}
+		r = rest
+
+		m = mimetype.Detect(header)
+		mime = m.String()
+	}
+
+	if *flagExec != "" {
+		if err := runExec(*flagExec, mime, header, r); err != nil {
+			return false, err
+		}
+	} else {
+		printResult(path, m, mime)
+	}
+
+	return mime != "application/octet-stream", nil
+}
+
+// openInput opens path (or stdin, for "-") and returns it along with the
+// io.Closer to release once done ("-" has none).
+func openInput(path string) (r io.Reader, closer io.Closer, err error) {
+	if path == "-" {
+		return os.Stdin, nil, nil
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return f, f, nil
+}
+
+// readHeader reads up to headerLen bytes from r. It returns the reader
+// positioned right after those bytes, so -exec can replay them ahead of
+// the rest of the stream.
+func readHeader(r io.Reader) (rest io.Reader, header []byte, err error) {
+	buf := make([]byte, headerLen)
+	n, err := io.ReadFull(r, buf)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, nil, err
+	}
+
+	return r, buf[:n], nil
+}
+
+// printResult writes the detection result for path in the format selected
+// by the -e, -mime-only, -json and -parents flags. m is nil when
+// detection was skipped because a -mime/-from hint was given, in which
+// case extension and parent information isn't available.
+func printResult(path string, m *mimetype.MIME, mime string) {
+	var extension string
+	var parents []string
+	if m != nil {
+		extension = m.Extension()
+		if *flagParents {
+			for p := m.Parent(); p != nil; p = p.Parent() {
+				parents = append(parents, p.String())
+			}
+		}
+	}
+
+	if *flagJSON {
+		enc := json.NewEncoder(os.Stdout)
+		_ = enc.Encode(struct {
+			Path      string   `json:"path"`
+			MIME      string   `json:"mime"`
+			Extension string   `json:"extension,omitempty"`
+			Parents   []string `json:"parents,omitempty"`
+		}{path, mime, extension, parents})
+		return
+	}
+
+	fields := []string{mime}
+	if *flagExtension {
+		fields = append(fields, extension)
+	}
+	if len(parents) > 0 {
+		fields = append(fields, strings.Join(parents, " < "))
+	}
+
+	line := strings.Join(fields, "\t")
+	if !*flagMIMEOnly && path != "-" {
+		line = path + ": " + line
+	}
+
+	fmt.Println(line)
+}
+
+// runExec looks up the first pattern=command pair in mapping whose
+// pattern matches mime and execs it, replaying header ahead of rest on
+// its stdin so the child sees the exact same bytes that were sniffed.
+func runExec(mapping, mime string, header []byte, rest io.Reader) error {
+	for _, pair := range strings.Split(mapping, ",") {
+		pattern, command, ok := strings.Cut(pair, "=")
+		if !ok {
+			continue
+		}
+		if !mimeMatches(mime, strings.TrimSpace(pattern)) {
+			continue
+		}
+
+		args := strings.Fields(strings.TrimSpace(command))
+		if len(args) == 0 {
+			continue
+		}
+
+		cmd := exec.Command(args[0], args[1:]...)
+		cmd.Stdin = io.MultiReader(bytes.NewReader(header), rest)
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = os.Stderr
+
+		return cmd.Run()
+	}
+
+	return fmt.Errorf("no -exec pattern matches %s", mime)
+}
+
+// mimeMatches reports whether mime satisfies pattern, which is either an
+// exact MIME type ("image/png") or a top-level wildcard ("image/*").
+func mimeMatches(mime, pattern string) bool {
+	if top, ok := strings.CutSuffix(pattern, "/*"); ok {
+		before, _, found := strings.Cut(mime, "/")
+		return found && before == top
+	}
+
+	return mime == pattern
+}
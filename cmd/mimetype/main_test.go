@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMimeMatches(t *testing.T) {
+	tests := []struct {
+		mime, pattern string
+		want          bool
+	}{
+		{"image/png", "image/*", true},
+		{"image/png", "image/png", true},
+		{"image/png", "image/jpeg", false},
+		{"audio/mpeg", "image/*", false},
+		{"application/octet-stream", "*", false},
+	}
+
+	for _, tt := range tests {
+		if got := mimeMatches(tt.mime, tt.pattern); got != tt.want {
+			t.Errorf("mimeMatches(%q, %q) = %v, want %v", tt.mime, tt.pattern, got, tt.want)
+		}
+	}
+}
+
+// resetFlags returns a func that restores every flag variable to its
+// current value, so a test can freely mutate them and clean up after
+// itself regardless of test order.
+func resetFlags() func() {
+	extension, mimeOnly, jsonOut, parents := *flagExtension, *flagMIMEOnly, *flagJSON, *flagParents
+	hint, hintFrom, execMapping := *flagHint, *flagHintFrom, *flagExec
+
+	return func() {
+		*flagExtension, *flagMIMEOnly, *flagJSON, *flagParents = extension, mimeOnly, jsonOut, parents
+		*flagHint, *flagHintFrom, *flagExec = hint, hintFrom, execMapping
+	}
+}
+
+// captureStdout runs fn with os.Stdout redirected and returns whatever it
+// wrote.
+func captureStdout(t *testing.T, fn func()) string {
+	t.Helper()
+
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	orig := os.Stdout
+	os.Stdout = w
+
+	fn()
+
+	os.Stdout = orig
+	w.Close()
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+
+	return buf.String()
+}
+
+func writeTempFile(t *testing.T, content []byte) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "input")
+	if err := os.WriteFile(path, content, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestDetectOneMatchesKnownType(t *testing.T) {
+	defer resetFlags()()
+
+	elf := append([]byte{0x7F, 'E', 'L', 'F'}, make([]byte, 16)...)
+	path := writeTempFile(t, elf)
+
+	var matched bool
+	var err error
+	out := captureStdout(t, func() {
+		matched, err = detectOne(path)
+	})
+
+	if err != nil {
+		t.Fatalf("detectOne(%s) error: %v", path, err)
+	}
+	if !matched {
+		t.Errorf("detectOne(%s) matched = false, want true", path)
+	}
+	if want := path + ": application/x-elf\n"; out != want {
+		t.Errorf("detectOne printed %q, want %q", out, want)
+	}
+}
+
+func TestDetectOneFallsBackToOctetStream(t *testing.T) {
+	defer resetFlags()()
+
+	path := writeTempFile(t, []byte{0x00, 0x01, 0x02, 0x03})
+
+	matched, err := detectOne(path)
+	if err != nil {
+		t.Fatalf("detectOne(%s) error: %v", path, err)
+	}
+	if matched {
+		t.Errorf("detectOne(%s) matched = true, want false (octet-stream fallback)", path)
+	}
+}
+
+func TestDetectOneMissingFile(t *testing.T) {
+	defer resetFlags()()
+
+	if _, err := detectOne(filepath.Join(t.TempDir(), "does-not-exist")); err == nil {
+		t.Error("detectOne on a missing file should return an error")
+	}
+}
+
+func TestDetectOneJSONAndParents(t *testing.T) {
+	defer resetFlags()()
+
+	*flagJSON = true
+	*flagParents = true
+
+	elf := append([]byte{0x7F, 'E', 'L', 'F'}, make([]byte, 16)...)
+	path := writeTempFile(t, elf)
+
+	out := captureStdout(t, func() {
+		if _, err := detectOne(path); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if !bytes.Contains([]byte(out), []byte(`"mime":"application/x-elf"`)) {
+		t.Errorf("JSON output = %q, want it to contain the detected mime", out)
+	}
+	if !bytes.Contains([]byte(out), []byte(`"parents":["application/octet-stream"]`)) {
+		t.Errorf("JSON output = %q, want it to contain the ancestor chain", out)
+	}
+}
+
+func TestDetectOneHintSkipsBlockingRead(t *testing.T) {
+	defer resetFlags()()
+
+	*flagHint = "text/plain"
+
+	pr, pw, err := os.Pipe() // never written to: reading from it would block forever.
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer pw.Close()
+	defer pr.Close()
+
+	origStdin := os.Stdin
+	os.Stdin = pr
+	defer func() { os.Stdin = origStdin }()
+
+	done := make(chan struct{})
+	var matched bool
+	var detectErr error
+	captureStdout(t, func() {
+		go func() {
+			matched, detectErr = detectOne("-")
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(2 * time.Second):
+			t.Fatal("detectOne blocked reading a header despite a -mime hint being set")
+		}
+	})
+
+	if detectErr != nil {
+		t.Fatalf("detectOne(-) error: %v", detectErr)
+	}
+	if !matched {
+		t.Error("detectOne(-) with a non-octet-stream hint should report matched = true")
+	}
+}
+
+func TestRunExecReplaysBufferedHeader(t *testing.T) {
+	header := []byte("head")
+	rest := bytes.NewBufferString("tail")
+
+	out := captureStdout(t, func() {
+		if err := runExec("text/*=cat", "text/plain", header, rest); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	if out != "headtail" {
+		t.Errorf("runExec replayed %q, want %q", out, "headtail")
+	}
+}
+
+func TestDetectOneExecDispatches(t *testing.T) {
+	defer resetFlags()()
+
+	*flagExec = "application/x-elf=cat"
+	elf := append([]byte{0x7F, 'E', 'L', 'F'}, []byte(" payload")...)
+	path := writeTempFile(t, elf)
+
+	out := captureStdout(t, func() {
+		matched, err := detectOne(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !matched {
+			t.Errorf("detectOne(%s) matched = false, want true", path)
+		}
+	})
+
+	if out != string(elf) {
+		t.Errorf("detectOne -exec output = %q, want the replayed file content %q", out, elf)
+	}
+}
+
+func TestRunExecNoMatchingPattern(t *testing.T) {
+	err := runExec("image/*=feh -", "text/plain", nil, bytes.NewReader(nil))
+	if err == nil {
+		t.Error("runExec should error when no pattern matches")
+	}
+}
@@ -0,0 +1,134 @@
+package matchers
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+// zstdMagic is the magic number of a standard Zstandard frame, as defined
+// in https://github.com/facebook/zstd/blob/dev/doc/zstd_compression_format.md#zstandard-frames.
+const zstdMagic = 0xFD2FB528
+
+// zstdSkippableMagicMask and zstdSkippableMagic identify a Zstandard
+// skippable frame: any magic number in the range 0x184D2A50-0x184D2A5F.
+// Some tools (e.g. zstd --format=zstd) prepend one of these to carry
+// metadata ahead of the first standard frame.
+const (
+	zstdSkippableMagicMask = 0xFFFFFFF0
+	zstdSkippableMagic     = 0x184D2A50
+)
+
+// Zstd matches a Zstandard archive that starts with either a standard
+// frame or a skippable frame. What follows a skippable frame is not
+// checked.
+func Zstd(in []byte, _ uint32) bool {
+	if len(in) < 4 {
+		return false
+	}
+
+	magic := binary.LittleEndian.Uint32(in)
+
+	return magic == zstdMagic || isZstdSkippableMagic(magic)
+}
+
+// isZstdSkippableMagic reports whether magic is the magic number of a
+// Zstandard skippable frame.
+func isZstdSkippableMagic(magic uint32) bool {
+	return magic&zstdSkippableMagicMask == zstdSkippableMagic
+}
+
+// ZstdSkippableFrame reports whether in starts with a Zstandard skippable
+// frame and, if so, how many bytes that frame occupies (the 8-byte header
+// plus its payload), so callers scanning a stream chunk by chunk can jump
+// straight to the next frame.
+func ZstdSkippableFrame(in []byte) (frameLen uint32, ok bool) {
+	if len(in) < 8 {
+		return 0, false
+	}
+
+	magic := binary.LittleEndian.Uint32(in)
+	if !isZstdSkippableMagic(magic) {
+		return 0, false
+	}
+
+	frameSize := binary.LittleEndian.Uint32(in[4:8])
+
+	return 8 + frameSize, true
+}
+
+// Rpm matches a RedHat Package Manager file.
+func Rpm(in []byte, _ uint32) bool {
+	return bytes.HasPrefix(in, []byte{0xED, 0xAB, 0xEE, 0xDB})
+}
+
+// arMagic is the 8-byte magic string at the start of every Unix ar
+// archive, regardless of what it contains.
+var arMagic = []byte("!<arch>\n")
+
+// Ar matches a Unix archive (the format produced by `ar`, e.g. .a static
+// libraries).
+func Ar(in []byte, _ uint32) bool {
+	return bytes.HasPrefix(in, arMagic)
+}
+
+// Deb matches a Debian package: a Unix ar archive whose first file entry
+// is "debian-binary".
+func Deb(in []byte, _ uint32) bool {
+	if !Ar(in, 0) || len(in) < len(arMagic)+16 {
+		return false
+	}
+
+	// Right after the 8-byte ar magic comes the first file header, whose
+	// first 16 bytes are the (space-padded) member name.
+	name := in[len(arMagic) : len(arMagic)+16]
+
+	return bytes.HasPrefix(name, []byte("debian-binary"))
+}
+
+// crxKnownVersions are the only version numbers the CRX2/CRX3 formats
+// have ever used for the dword right after the magic number.
+var crxKnownVersions = map[uint32]bool{2: true, 3: true}
+
+// Crx matches a Google Chrome extension: the "Cr24" magic followed by a
+// 4-byte little-endian version dword.
+func Crx(in []byte, _ uint32) bool {
+	if !bytes.HasPrefix(in, []byte("Cr24")) || len(in) < 8 {
+		return false
+	}
+
+	return crxKnownVersions[binary.LittleEndian.Uint32(in[4:8])]
+}
+
+// Cab matches a Microsoft Cabinet archive.
+func Cab(in []byte, _ uint32) bool {
+	return bytes.HasPrefix(in, []byte("MSCF\x00\x00\x00\x00"))
+}
+
+// Eot matches an Embedded OpenType font, whose signature is not a leading
+// magic number but a fixed pair of bytes deeper into the header.
+// https://www.w3.org/submissions/EOT/#FontFileEOTHeader
+func Eot(in []byte, _ uint32) bool {
+	if len(in) < 36 {
+		return false
+	}
+
+	if !bytes.Equal(in[34:36], []byte{0x4C, 0x50}) {
+		return false
+	}
+
+	version := in[8:11]
+
+	return bytes.Equal(version, []byte{0x02, 0x00, 0x01}) ||
+		bytes.Equal(version, []byte{0x01, 0x00, 0x00}) ||
+		bytes.Equal(version, []byte{0x02, 0x00, 0x02})
+}
+
+// Lzip matches an Lzip compressed file.
+func Lzip(in []byte, _ uint32) bool {
+	return bytes.HasPrefix(in, []byte("LZIP"))
+}
+
+// Compress matches a Unix compress(1) file (the classic .Z format).
+func Compress(in []byte, _ uint32) bool {
+	return bytes.HasPrefix(in, []byte{0x1F, 0x9D}) || bytes.HasPrefix(in, []byte{0x1F, 0xA0})
+}
@@ -0,0 +1,116 @@
+package matchers
+
+import (
+	"encoding/binary"
+	"testing"
+)
+
+func zstdFrame() []byte {
+	frame := make([]byte, 4)
+	binary.LittleEndian.PutUint32(frame, zstdMagic)
+	return frame
+}
+
+func zstdSkippableFrame(payloadLen uint32) []byte {
+	frame := make([]byte, 8+payloadLen)
+	binary.LittleEndian.PutUint32(frame[:4], zstdSkippableMagic)
+	binary.LittleEndian.PutUint32(frame[4:8], payloadLen)
+	return frame
+}
+
+func TestZstd(t *testing.T) {
+	tests := []struct {
+		name string
+		in   []byte
+		want bool
+	}{
+		{"standard frame", zstdFrame(), true},
+		{"skippable frame alone", zstdSkippableFrame(4), true},
+		{"skippable frame, highest variant", func() []byte {
+			f := zstdSkippableFrame(0)
+			binary.LittleEndian.PutUint32(f[:4], 0x184D2A5F)
+			return f
+		}(), true},
+		{"skippable frame followed by standard frame", append(zstdSkippableFrame(4), zstdFrame()...), true},
+		{"unrelated magic", []byte{0x00, 0x01, 0x02, 0x03}, false},
+		{"too short", []byte{0x28, 0xB5}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Zstd(tt.in, 0); got != tt.want {
+				t.Errorf("Zstd(%x) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestZstdSkippableFrame(t *testing.T) {
+	payload := zstdSkippableFrame(12)
+	frameLen, ok := ZstdSkippableFrame(payload)
+	if !ok {
+		t.Fatalf("ZstdSkippableFrame(%x) reported not skippable", payload)
+	}
+	if want := uint32(8 + 12); frameLen != want {
+		t.Errorf("ZstdSkippableFrame frameLen = %d, want %d", frameLen, want)
+	}
+
+	if _, ok := ZstdSkippableFrame(zstdFrame()); ok {
+		t.Error("ZstdSkippableFrame should report false for a standard frame")
+	}
+}
+
+func debFile() []byte {
+	in := append([]byte{}, arMagic...)
+	name := make([]byte, 16)
+	copy(name, "debian-binary/")
+	for i := len("debian-binary/"); i < len(name); i++ {
+		name[i] = ' '
+	}
+	return append(in, name...)
+}
+
+func TestPackageMatchers(t *testing.T) {
+	tests := []struct {
+		name  string
+		match func(in []byte, limit uint32) bool
+		in    []byte
+		want  bool
+	}{
+		{"rpm", Rpm, []byte{0xED, 0xAB, 0xEE, 0xDB, 0x03, 0x00}, true},
+		{"rpm mismatch", Rpm, []byte{0x00, 0x01, 0x02, 0x03}, false},
+		{"ar", Ar, []byte("!<arch>\n"), true},
+		{"deb", Deb, debFile(), true},
+		{"ar but not deb", Deb, append([]byte("!<arch>\n"), []byte("something.o/    ")...), false},
+		{"crx", Crx, []byte("Cr24\x02\x00\x00\x00"), true},
+		{"crx v3", Crx, []byte("Cr24\x03\x00\x00\x00"), true},
+		{"crx unknown version", Crx, []byte("Cr24\x99\x00\x00\x00"), false},
+		{"crx magic without version dword", Crx, []byte("Cr24"), false},
+		{"cab", Cab, []byte("MSCF\x00\x00\x00\x00"), true},
+		{"lzip", Lzip, []byte("LZIP\x01"), true},
+		{"compress .Z variant 1", Compress, []byte{0x1F, 0x9D}, true},
+		{"compress .Z variant 2", Compress, []byte{0x1F, 0xA0}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.match(tt.in, 0); got != tt.want {
+				t.Errorf("%s(%x) = %v, want %v", tt.name, tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEot(t *testing.T) {
+	in := make([]byte, 36)
+	copy(in[8:11], []byte{0x02, 0x00, 0x01})
+	copy(in[34:36], []byte{0x4C, 0x50})
+
+	if !Eot(in, 0) {
+		t.Errorf("Eot(%x) = false, want true", in)
+	}
+
+	if Eot(make([]byte, 36), 0) {
+		t.Error("Eot should require the LP magic at offset 34 and a known version")
+	}
+}
@@ -0,0 +1,36 @@
+package matchers
+
+import "encoding/binary"
+
+// ElfDetails holds the fields of an ELF header needed to tell binaries
+// apart by target platform, read from the identification block
+// (e_ident) and the fields right after it.
+type ElfDetails struct {
+	Is64    bool
+	OSABI   byte
+	Machine uint16
+}
+
+// ElfDetailsOf parses the ELF header Elf already recognized in in and
+// reports the platform it targets. It reports ok = false if in is too
+// short to hold the fields it needs.
+func ElfDetailsOf(in []byte) (details ElfDetails, ok bool) {
+	// e_ident[EI_CLASS] at offset 0x04: 1 means 32-bit, 2 means 64-bit.
+	// e_ident[EI_DATA] at offset 0x05: 1 means little-endian, 2 big-endian.
+	// e_ident[EI_OSABI] at offset 0x07.
+	// e_machine at offset 0x12, 2 bytes, in the file's own byte order.
+	if len(in) < 0x14 {
+		return ElfDetails{}, false
+	}
+
+	order := binary.ByteOrder(binary.LittleEndian)
+	if in[0x05] == 2 {
+		order = binary.BigEndian
+	}
+
+	return ElfDetails{
+		Is64:    in[0x04] == 2,
+		OSABI:   in[0x07],
+		Machine: order.Uint16(in[0x12:0x14]),
+	}, true
+}
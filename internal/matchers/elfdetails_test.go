@@ -0,0 +1,40 @@
+package matchers
+
+import "testing"
+
+func elfFile(class, data byte, osabi byte, machine uint16, order func([]byte, uint16)) []byte {
+	in := make([]byte, 0x14)
+	copy(in, []byte{0x7F, 'E', 'L', 'F'})
+	in[0x04] = class
+	in[0x05] = data
+	in[0x07] = osabi
+	order(in[0x12:0x14], machine)
+	return in
+}
+
+func TestElfDetailsOf(t *testing.T) {
+	le := func(b []byte, v uint16) { b[0] = byte(v); b[1] = byte(v >> 8) }
+	be := func(b []byte, v uint16) { b[0] = byte(v >> 8); b[1] = byte(v) }
+
+	in := elfFile(2, 1, 0x03, 0x3E, le) // 64-bit, little-endian, Linux, x86-64
+	d, ok := ElfDetailsOf(in)
+	if !ok {
+		t.Fatalf("ElfDetailsOf(%x) reported not ok", in)
+	}
+	if !d.Is64 || d.OSABI != 0x03 || d.Machine != 0x3E {
+		t.Errorf("ElfDetailsOf = %+v, want Is64=true OSABI=0x03 Machine=0x3E", d)
+	}
+
+	in = elfFile(1, 2, 0x00, 0x28, be) // 32-bit, big-endian, ARM
+	d, ok = ElfDetailsOf(in)
+	if !ok {
+		t.Fatalf("ElfDetailsOf(%x) reported not ok", in)
+	}
+	if d.Is64 || d.Machine != 0x28 {
+		t.Errorf("ElfDetailsOf = %+v, want Is64=false Machine=0x28", d)
+	}
+
+	if _, ok := ElfDetailsOf([]byte{0x7F, 'E', 'L', 'F'}); ok {
+		t.Error("ElfDetailsOf should fail on a truncated header")
+	}
+}
@@ -0,0 +1,94 @@
+package matchers
+
+import (
+	"debug/macho"
+	"encoding/binary"
+)
+
+// FatArch describes one architecture slice embedded in a Mach-O fat
+// (universal) binary, mirroring mach-o/fat.h's fat_arch.
+type FatArch struct {
+	CPUType    uint32
+	CPUSubtype uint32
+	Offset     uint32
+	Size       uint32
+}
+
+// MachODetails holds the architecture metadata of a Mach-O binary. For a
+// thin binary, CPUType/CPUSubtype describe its single architecture and
+// Fat is false. For a fat binary, Fat is true and Archs holds one entry
+// per embedded architecture.
+type MachODetails struct {
+	Fat        bool
+	CPUType    uint32
+	CPUSubtype uint32
+	Archs      []FatArch
+}
+
+// MachODetailsOf parses the Mach-O (or fat Mach-O) header MachO already
+// recognized in in, and reports the architecture(s) it declares. It
+// reports ok = false if in is too short to hold the fields it needs.
+func MachODetailsOf(in []byte) (details MachODetails, ok bool) {
+	if classOrMachOFat(in) && in[7] < 20 {
+		return machODetailsFat(in)
+	}
+
+	return machODetailsThin(in)
+}
+
+// machODetailsThin reads the cputype/cpusubtype fields that follow the
+// magic number of a non-fat mach_header(_64). Those fields, like the
+// magic number itself, can be stored either big- or little-endian.
+func machODetailsThin(in []byte) (MachODetails, bool) {
+	if len(in) < 12 {
+		return MachODetails{}, false
+	}
+
+	be := binary.BigEndian.Uint32(in)
+
+	order := binary.ByteOrder(binary.LittleEndian)
+	if be == macho.Magic32 || be == macho.Magic64 {
+		order = binary.BigEndian
+	}
+
+	return MachODetails{
+		CPUType:    order.Uint32(in[4:8]),
+		CPUSubtype: order.Uint32(in[8:12]),
+	}, true
+}
+
+// machODetailsFat reads the big-endian fat_header/fat_arch structures
+// that describe each architecture embedded in a fat (universal) binary.
+func machODetailsFat(in []byte) (MachODetails, bool) {
+	if len(in) < 8 {
+		return MachODetails{}, false
+	}
+
+	nArch := binary.BigEndian.Uint32(in[4:8])
+	const archSize = 20 // sizeof(fat_arch): 5 big-endian uint32 fields.
+
+	// nArch comes straight from the input and is not trustworthy: cap the
+	// preallocation at the number of arches the buffer could actually
+	// hold, so a crafted header can't force a multi-gigabyte allocation.
+	maxArchs := (len(in) - 8) / archSize
+	capHint := int(nArch)
+	if capHint > maxArchs {
+		capHint = maxArchs
+	}
+
+	archs := make([]FatArch, 0, capHint)
+	for i, off := uint32(0), 8; i < nArch; i, off = i+1, off+archSize {
+		if len(in) < off+archSize {
+			break
+		}
+
+		archs = append(archs, FatArch{
+			CPUType:    binary.BigEndian.Uint32(in[off : off+4]),
+			CPUSubtype: binary.BigEndian.Uint32(in[off+4 : off+8]),
+			Offset:     binary.BigEndian.Uint32(in[off+8 : off+12]),
+			Size:       binary.BigEndian.Uint32(in[off+12 : off+16]),
+		})
+	}
+
+	return MachODetails{Fat: true, Archs: archs}, true
+}
@@ -0,0 +1,73 @@
+package matchers
+
+import (
+	"debug/macho"
+	"encoding/binary"
+	"testing"
+)
+
+func TestMachODetailsOfThin(t *testing.T) {
+	in := make([]byte, 12)
+	binary.BigEndian.PutUint32(in[0:4], macho.Magic32)
+	binary.BigEndian.PutUint32(in[4:8], 7)  // CPU_TYPE_X86
+	binary.BigEndian.PutUint32(in[8:12], 3) // CPU_SUBTYPE_X86_ALL
+
+	d, ok := MachODetailsOf(in)
+	if !ok {
+		t.Fatalf("MachODetailsOf(%x) reported not ok", in)
+	}
+	if d.Fat || d.CPUType != 7 || d.CPUSubtype != 3 {
+		t.Errorf("MachODetailsOf = %+v, want thin binary CPUType=7 CPUSubtype=3", d)
+	}
+}
+
+func TestMachODetailsOfFat(t *testing.T) {
+	in := make([]byte, 8+20)
+	copy(in[:4], []byte{0xCA, 0xFE, 0xBA, 0xBE})
+	binary.BigEndian.PutUint32(in[4:8], 1) // nfat_arch
+	binary.BigEndian.PutUint32(in[8:12], 0x01000007)
+	binary.BigEndian.PutUint32(in[12:16], 3)
+	binary.BigEndian.PutUint32(in[16:20], 0x1000)
+	binary.BigEndian.PutUint32(in[20:24], 0x2000)
+
+	d, ok := MachODetailsOf(in)
+	if !ok {
+		t.Fatalf("MachODetailsOf(%x) reported not ok", in)
+	}
+	if !d.Fat || len(d.Archs) != 1 {
+		t.Fatalf("MachODetailsOf = %+v, want one fat arch", d)
+	}
+	got := d.Archs[0]
+	if got.CPUType != 0x01000007 || got.CPUSubtype != 3 || got.Offset != 0x1000 || got.Size != 0x2000 {
+		t.Errorf("Archs[0] = %+v, want CPUType=0x01000007 CPUSubtype=3 Offset=0x1000 Size=0x2000", got)
+	}
+}
+
+// TestMachODetailsOfFatHugeNArch guards against a crafted fat header whose
+// nfat_arch claims far more entries than the buffer can hold. The low
+// byte of nfat_arch must stay under 20 to satisfy the fat/Class gate in
+// classOrMachOFat's callers, but the upper bytes can still encode a huge
+// count, so this must not translate into a multi-gigabyte preallocation.
+func TestMachODetailsOfFatHugeNArch(t *testing.T) {
+	in := make([]byte, 8+20) // header plus a single real fat_arch entry
+	copy(in[:4], []byte{0xCA, 0xFE, 0xBA, 0xBE})
+	binary.BigEndian.PutUint32(in[4:8], 0xFFFFFF00) // huge nfat_arch, low byte 0
+	binary.BigEndian.PutUint32(in[8:12], 7)
+	binary.BigEndian.PutUint32(in[12:16], 3)
+	binary.BigEndian.PutUint32(in[16:20], 0x1000)
+	binary.BigEndian.PutUint32(in[20:24], 0x2000)
+
+	d, ok := MachODetailsOf(in)
+	if !ok {
+		t.Fatalf("MachODetailsOf(%x) reported not ok", in)
+	}
+	if !d.Fat {
+		t.Fatalf("MachODetailsOf = %+v, want Fat=true", d)
+	}
+	// Only one fat_arch actually fits in the buffer; the bogus count must
+	// not be trusted for more than that, and must not have caused a huge
+	// allocation along the way.
+	if len(d.Archs) != 1 {
+		t.Errorf("MachODetailsOf = %+v, want exactly one parsed arch", d)
+	}
+}
@@ -0,0 +1,46 @@
+package matchers
+
+import "encoding/binary"
+
+// PEDetails holds the fields of a PE (Portable Executable) header needed
+// to tell binaries apart by target architecture.
+type PEDetails struct {
+	Is64    bool
+	Machine uint16
+}
+
+// peMachine values from the PE spec's IMAGE_FILE_HEADER.Machine field
+// that distinguish 64-bit architectures from their 32-bit counterparts.
+const (
+	peMachineAMD64 = 0x8664
+	peMachineARM64 = 0xAA64
+	peMachineIA64  = 0x0200
+)
+
+// PEDetailsOf parses the PE header of the Windows/DOS executable Exe
+// already recognized in in and reports the architecture it targets. It
+// follows the e_lfanew pointer at offset 0x3C to the "PE\0\0" header and
+// reads its Machine field. It reports ok = false if in doesn't contain a
+// complete, valid PE header (e.g. it's a plain DOS executable).
+func PEDetailsOf(in []byte) (details PEDetails, ok bool) {
+	if len(in) < 0x40 {
+		return PEDetails{}, false
+	}
+
+	peOffset := binary.LittleEndian.Uint32(in[0x3C:0x40])
+	if uint64(peOffset)+24 > uint64(len(in)) {
+		return PEDetails{}, false
+	}
+
+	peHeader := in[peOffset:]
+	if !(peHeader[0] == 'P' && peHeader[1] == 'E' && peHeader[2] == 0 && peHeader[3] == 0) {
+		return PEDetails{}, false
+	}
+
+	machine := binary.LittleEndian.Uint16(peHeader[4:6])
+
+	return PEDetails{
+		Is64:    machine == peMachineAMD64 || machine == peMachineARM64 || machine == peMachineIA64,
+		Machine: machine,
+	}, true
+}
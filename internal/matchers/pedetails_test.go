@@ -0,0 +1,37 @@
+package matchers
+
+import "testing"
+
+func peFile(machine uint16) []byte {
+	in := make([]byte, 0x40+24)
+	// e_lfanew at 0x3C points right after the DOS header.
+	in[0x3C] = 0x40
+	copy(in[0x40:], []byte("PE\x00\x00"))
+	in[0x40+4] = byte(machine)
+	in[0x40+5] = byte(machine >> 8)
+	return in
+}
+
+func TestPEDetailsOf(t *testing.T) {
+	in := peFile(peMachineAMD64)
+	d, ok := PEDetailsOf(in)
+	if !ok {
+		t.Fatalf("PEDetailsOf(%x) reported not ok", in)
+	}
+	if !d.Is64 || d.Machine != peMachineAMD64 {
+		t.Errorf("PEDetailsOf = %+v, want Is64=true Machine=%#x", d, peMachineAMD64)
+	}
+
+	in = peFile(0x14C) // IMAGE_FILE_MACHINE_I386
+	d, ok = PEDetailsOf(in)
+	if !ok {
+		t.Fatalf("PEDetailsOf(%x) reported not ok", in)
+	}
+	if d.Is64 {
+		t.Errorf("PEDetailsOf(x86) reported Is64=true")
+	}
+
+	if _, ok := PEDetailsOf([]byte{0x4D, 0x5A}); ok {
+		t.Error("PEDetailsOf should fail on a truncated DOS header")
+	}
+}
@@ -0,0 +1,96 @@
+package mimetype
+
+// MIME struct holds information about a file format: its MIME type, its
+// file extension, and its parent type, if any, in the detection tree
+// rooted at "application/octet-stream".
+type MIME struct {
+	mime      string
+	extension string
+	detector  func(raw []byte, limit uint32) bool
+	details   func(raw []byte) interface{}
+	children  []*MIME
+	parent    *MIME
+}
+
+// String returns the string representation of the MIME type, e.g.
+// "application/zip".
+func (m *MIME) String() string {
+	return m.mime
+}
+
+// Extension returns the file extension associated with the MIME type. It
+// includes the leading dot, as in ".html". When the type has no
+// extension, it returns an empty string.
+func (m *MIME) Extension() string {
+	return m.extension
+}
+
+// Parent returns the parent MIME type from the detection tree. For
+// example, the parent of "application/vnd.debian.binary-package" is
+// "application/x-unix-archive". The root of the
+// tree, "application/octet-stream", returns a nil parent.
+func (m *MIME) Parent() *MIME {
+	return m.parent
+}
+
+// Is reports whether m, or any of its ancestors in the detection tree, has
+// the given MIME type. Aliases are not considered.
+func (m *MIME) Is(expectedMIME string) bool {
+	for mime := m; mime != nil; mime = mime.parent {
+		if mime.mime == expectedMIME {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Details returns format-specific metadata extracted from raw alongside
+// m's plain MIME/extension determination -- for example a Mach-O
+// binary's CPU architecture, an ELF's target machine, or a PE's
+// bitness. Its concrete type depends on m; see package internal/matchers
+// for the possible types. It returns nil for MIME types that don't
+// expose structured metadata, or when raw doesn't hold enough data for
+// extraction to succeed.
+func (m *MIME) Details(raw []byte) interface{} {
+	if m.details == nil {
+		return nil
+	}
+
+	return m.details(raw)
+}
+
+// withDetails attaches a details extractor to m and returns m, so it can
+// be chained directly where m is declared in the detection tree.
+func (m *MIME) withDetails(details func(raw []byte) interface{}) *MIME {
+	m.details = details
+	return m
+}
+
+// newMIME creates a new node of the detection tree. children are tried,
+// in order, whenever mime matches, and are considered more specific than
+// mime itself.
+func newMIME(mime, extension string, detector func(raw []byte, limit uint32) bool, children ...*MIME) *MIME {
+	m := &MIME{
+		mime:      mime,
+		extension: extension,
+		detector:  detector,
+		children:  children,
+	}
+	for _, c := range children {
+		c.parent = m
+	}
+
+	return m
+}
+
+// match finds the most specific matching node in the subtree rooted at m.
+func (m *MIME) match(in []byte, limit uint32) *MIME {
+	for _, c := range m.children {
+		if c.detector(in, limit) {
+			return c.match(in, limit)
+		}
+	}
+
+	return m
+}
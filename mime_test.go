@@ -0,0 +1,35 @@
+package mimetype
+
+import (
+	"testing"
+
+	"github.com/YinTokey/mimetype/internal/matchers"
+)
+
+func TestMIMEDetailsElf(t *testing.T) {
+	in := make([]byte, 0x14)
+	copy(in, []byte{0x7F, 'E', 'L', 'F'})
+	in[0x04] = 2 // 64-bit
+	in[0x05] = 1 // little-endian
+	in[0x12] = 0x3E
+
+	m := Detect(in)
+	if !m.Is("application/x-elf") {
+		t.Fatalf("Detect(%x) = %q, want application/x-elf", in, m)
+	}
+
+	got, ok := m.Details(in).(matchers.ElfDetails)
+	if !ok {
+		t.Fatalf("Details(%x) = %#v, want matchers.ElfDetails", in, m.Details(in))
+	}
+	if !got.Is64 || got.Machine != 0x3E {
+		t.Errorf("Details = %+v, want Is64=true Machine=0x3E", got)
+	}
+}
+
+func TestMIMEDetailsNilWhenUnsupported(t *testing.T) {
+	m := Detect([]byte("plain text"))
+	if d := m.Details([]byte("plain text")); d != nil {
+		t.Errorf("Details on a type with no extractor = %#v, want nil", d)
+	}
+}
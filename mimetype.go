@@ -0,0 +1,16 @@
+package mimetype
+
+// defaultLimit is the cap applied to Detect when it is handed a larger
+// slice: only the first defaultLimit bytes are examined.
+var defaultLimit uint32 = 3072
+
+// Detect returns the MIME type found from the provided byte slice, walking
+// the detection tree from its root, "application/octet-stream", down to
+// the most specific matching node. Detect always returns a non-nil MIME.
+func Detect(in []byte) *MIME {
+	if uint32(len(in)) > defaultLimit {
+		in = in[:defaultLimit]
+	}
+
+	return root.match(in, defaultLimit)
+}
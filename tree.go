@@ -0,0 +1,54 @@
+package mimetype
+
+import "github.com/YinTokey/mimetype/internal/matchers"
+
+// root is the root of the detection tree. Its detector always matches, so
+// Detect falls back to it, "application/octet-stream", whenever none of
+// its descendants recognize the input.
+var root = newMIME("application/octet-stream", "", func([]byte, uint32) bool { return true },
+	newMIME("application/wasm", ".wasm", matchers.Wasm),
+	newMIME("application/x-shockwave-flash", ".swf", matchers.Swf),
+	newMIME("application/x-mach-binary", "", matchers.MachO).withDetails(machODetails),
+	newMIME("application/x-elf", "", matchers.Elf).withDetails(elfDetails),
+	newMIME("application/x-msdownload", ".exe", matchers.Exe).withDetails(peDetails),
+	newMIME("application/zstd", ".zst", matchers.Zstd),
+	newMIME("application/x-rpm", ".rpm", matchers.Rpm),
+	newMIME("application/x-unix-archive", ".a", matchers.Ar,
+		newMIME("application/vnd.debian.binary-package", ".deb", matchers.Deb),
+	),
+	newMIME("application/x-google-chrome-extension", ".crx", matchers.Crx),
+	newMIME("application/vnd.ms-cab-compressed", ".cab", matchers.Cab),
+	newMIME("application/vnd.ms-fontobject", ".eot", matchers.Eot),
+	newMIME("application/x-lzip", ".lz", matchers.Lzip),
+	newMIME("application/x-compress", ".Z", matchers.Compress),
+)
+
+// machODetails adapts matchers.MachODetailsOf to the MIME.details shape.
+func machODetails(raw []byte) interface{} {
+	d, ok := matchers.MachODetailsOf(raw)
+	if !ok {
+		return nil
+	}
+
+	return d
+}
+
+// elfDetails adapts matchers.ElfDetailsOf to the MIME.details shape.
+func elfDetails(raw []byte) interface{} {
+	d, ok := matchers.ElfDetailsOf(raw)
+	if !ok {
+		return nil
+	}
+
+	return d
+}
+
+// peDetails adapts matchers.PEDetailsOf to the MIME.details shape.
+func peDetails(raw []byte) interface{} {
+	d, ok := matchers.PEDetailsOf(raw)
+	if !ok {
+		return nil
+	}
+
+	return d
+}
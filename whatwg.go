@@ -0,0 +1,324 @@
+package mimetype
+
+import (
+	"bytes"
+	"encoding/binary"
+
+	"github.com/YinTokey/mimetype/internal/matchers"
+)
+
+// sniffLen is the number of leading bytes DetectContentType looks at, per
+// https://mimesniff.spec.whatwg.org/#reading-the-resource-header.
+const sniffLen = 512
+
+// DetectContentType reports the MIME type of in using the WHATWG MIME
+// Sniffing algorithm (https://mimesniff.spec.whatwg.org/), the same one
+// browsers use and that Go's net/http.DetectContentType implements. Unlike
+// Detect, which walks the richer, heuristic tree of matchers in this
+// package, DetectContentType only ever returns a type backed by one of the
+// spec's signatures, so callers that must agree with how a browser will
+// sniff the same bytes (e.g. an HTTP server setting Content-Type) should
+// use this instead. It always returns a valid MIME type, falling back to
+// "application/octet-stream" when nothing matches.
+//
+// whatwgSignatures mirrors the table net/http's sniff.go builds from the
+// same spec, entry for entry, so the two stay in agreement; formats that
+// neither the spec nor net/http sniffs (such as ELF or PE binaries) are
+// deliberately left out of it, even though Detect recognizes them.
+func DetectContentType(in []byte) string {
+	if len(in) > sniffLen {
+		in = in[:sniffLen]
+	}
+
+	firstNonWS := 0
+	for firstNonWS < len(in) && isSniffWhitespace(in[firstNonWS]) {
+		firstNonWS++
+	}
+
+	for _, sig := range whatwgSignatures {
+		if ct := sig.match(in, firstNonWS); ct != "" {
+			return ct
+		}
+	}
+
+	return "application/octet-stream"
+}
+
+// isSniffWhitespace reports whether b is one of the five bytes the spec
+// treats as leading whitespace to be skipped before sniffing tag-based
+// signatures.
+func isSniffWhitespace(b byte) bool {
+	switch b {
+	case 0x09, 0x0A, 0x0C, 0x0D, 0x20:
+		return true
+	}
+	return false
+}
+
+// whatwgSig matches in, the (unmodified) sniffed header, against a single
+// entry of the spec's signature table and returns the MIME type it stands
+// for, or "" if the signature does not apply. firstNonWS is the index of
+// the first non-whitespace byte in in, needed by the few signatures the
+// spec defines as whitespace-tolerant.
+type whatwgSig interface {
+	match(in []byte, firstNonWS int) string
+}
+
+// exactSig matches when in begins with pat.
+type exactSig struct {
+	pat []byte
+	ct  string
+}
+
+func (e exactSig) match(in []byte, firstNonWS int) string {
+	if bytes.HasPrefix(in, e.pat) {
+		return e.ct
+	}
+	return ""
+}
+
+// maskedSig matches when, after optionally skipping leading whitespace,
+// every byte of in ANDed with the matching byte of mask equals pat.
+type maskedSig struct {
+	mask, pat []byte
+	skipWS    bool
+	ct        string
+}
+
+func (m maskedSig) match(in []byte, firstNonWS int) string {
+	if m.skipWS {
+		in = in[firstNonWS:]
+	}
+	if len(in) < len(m.pat) {
+		return ""
+	}
+	for i, b := range in[:len(m.pat)] {
+		if b&m.mask[i] != m.pat[i] {
+			return ""
+		}
+	}
+	return m.ct
+}
+
+// htmlSig matches an HTML/XML tag signature: the tag name, compared
+// case-insensitively, must be followed by a tag-terminating byte (space or
+// '>') so that e.g. the "<A" signature does not match "<ABBR".
+type htmlSig struct {
+	tag []byte
+	ct  string
+}
+
+func (h htmlSig) match(in []byte, firstNonWS int) string {
+	in = in[firstNonWS:]
+	if len(in) < len(h.tag)+1 {
+		return ""
+	}
+	for i, b := range h.tag {
+		db := in[i]
+		if 'a' <= db && db <= 'z' {
+			db -= 0x20
+		}
+		if b != db {
+			return ""
+		}
+	}
+	if last := in[len(h.tag)]; last != 0x20 && last != 0x3E {
+		return ""
+	}
+	return h.ct
+}
+
+// mp4Sig implements the MP4 box-walking algorithm from
+// https://mimesniff.spec.whatwg.org/#signature-for-mp4: read a big-endian
+// box size, confirm the box type at offset 4 is "ftyp", then scan the
+// major and compatible brands in 4-byte steps (skipping the minor version
+// at offset 12) looking for "mp4".
+type mp4Sig struct{}
+
+func (mp4Sig) match(in []byte, firstNonWS int) string {
+	if len(in) < 12 {
+		return ""
+	}
+
+	boxSize := int(binary.BigEndian.Uint32(in[:4]))
+	if len(in) < boxSize || boxSize%4 != 0 {
+		return ""
+	}
+
+	if !bytes.Equal(in[4:8], []byte("ftyp")) {
+		return ""
+	}
+
+	for st := 8; st < boxSize; st += 4 {
+		if st == 12 {
+			// Bytes 12-16 hold the minor version, not a brand.
+			continue
+		}
+		if bytes.Equal(in[st:st+3], []byte("mp4")) {
+			return "video/mp4"
+		}
+	}
+
+	return ""
+}
+
+// matcherSig adapts one of the exact-magic detectors already implemented
+// in internal/matchers so the spec table and the richer tree-based
+// matcher share a single source of truth for formats both agree on.
+type matcherSig struct {
+	detect func(in []byte, limit uint32) bool
+	ct     string
+}
+
+func (m matcherSig) match(in []byte, firstNonWS int) string {
+	if m.detect(in, uint32(sniffLen)) {
+		return m.ct
+	}
+	return ""
+}
+
+// textSig is the spec's catch-all: if nothing more specific matched and
+// the sniffed bytes after any leading whitespace contain none of the
+// spec's binary data bytes (0x00-0x08, 0x0B, 0x0E-0x1A, 0x1C-0x1F), the
+// resource is treated as plain text.
+type textSig struct{}
+
+func (textSig) match(in []byte, firstNonWS int) string {
+	for _, b := range in[firstNonWS:] {
+		switch {
+		case b <= 0x08,
+			b == 0x0B,
+			0x0E <= b && b <= 0x1A,
+			0x1C <= b && b <= 0x1F:
+			return ""
+		}
+	}
+	return "text/plain; charset=utf-8"
+}
+
+// whatwgSignatures is the ordered signature table from
+// https://mimesniff.spec.whatwg.org/#matching-a-mime-type-pattern, laid out
+// in the same order as net/http's sniffSignatures so a reader can diff the
+// two. Order matters: the first matching entry wins. Only matchers.Wasm is
+// pulled in from the richer tree in internal/matchers, because it's the one
+// binary format the spec (and net/http) actually sniffs; ELF, PE and SWF
+// are Detect-only extensions and must not appear here.
+var whatwgSignatures = []whatwgSig{
+	htmlSig{[]byte("<!DOCTYPE HTML"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<HTML"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<HEAD"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<SCRIPT"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<IFRAME"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<H1"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<DIV"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<FONT"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<TABLE"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<A"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<STYLE"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<TITLE"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<B"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<BODY"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<BR"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<P"), "text/html; charset=utf-8"},
+	htmlSig{[]byte("<!--"), "text/html; charset=utf-8"},
+	maskedSig{
+		mask:   []byte("\xFF\xFF\xFF\xFF\xFF"),
+		pat:    []byte("<?xml"),
+		skipWS: true,
+		ct:     "text/xml; charset=utf-8",
+	},
+	exactSig{[]byte("%PDF-"), "application/pdf"},
+	exactSig{[]byte("%!PS-Adobe-"), "application/postscript"},
+
+	// UTF BOMs.
+	maskedSig{
+		mask: []byte("\xFF\xFF\x00\x00"),
+		pat:  []byte("\xFE\xFF\x00\x00"),
+		ct:   "text/plain; charset=utf-16be",
+	},
+	maskedSig{
+		mask: []byte("\xFF\xFF\x00\x00"),
+		pat:  []byte("\xFF\xFE\x00\x00"),
+		ct:   "text/plain; charset=utf-16le",
+	},
+	maskedSig{
+		mask: []byte("\xFF\xFF\xFF\x00"),
+		pat:  []byte("\xEF\xBB\xBF\x00"),
+		ct:   "text/plain; charset=utf-8",
+	},
+
+	// Image types.
+	exactSig{[]byte("\x00\x00\x01\x00"), "image/x-icon"},
+	exactSig{[]byte("\x00\x00\x02\x00"), "image/x-icon"},
+	exactSig{[]byte("BM"), "image/bmp"},
+	exactSig{[]byte("GIF87a"), "image/gif"},
+	exactSig{[]byte("GIF89a"), "image/gif"},
+	maskedSig{
+		mask: []byte("\xFF\xFF\xFF\xFF\x00\x00\x00\x00\xFF\xFF\xFF\xFF\xFF\xFF"),
+		pat:  []byte("RIFF\x00\x00\x00\x00WEBPVP"),
+		ct:   "image/webp",
+	},
+	exactSig{[]byte("\x89PNG\r\n\x1A\n"), "image/png"},
+	exactSig{[]byte("\xFF\xD8\xFF"), "image/jpeg"},
+
+	// Audio and video types.
+	maskedSig{
+		mask: []byte("\xFF\xFF\xFF\xFF\x00\x00\x00\x00\xFF\xFF\xFF\xFF"),
+		pat:  []byte("FORM\x00\x00\x00\x00AIFF"),
+		ct:   "audio/aiff",
+	},
+	maskedSig{
+		mask: []byte("\xFF\xFF\xFF"),
+		pat:  []byte("ID3"),
+		ct:   "audio/mpeg",
+	},
+	maskedSig{
+		mask: []byte("\xFF\xFF\xFF\xFF\xFF"),
+		pat:  []byte("OggS\x00"),
+		ct:   "application/ogg",
+	},
+	maskedSig{
+		mask: []byte("\xFF\xFF\xFF\xFF\xFF\xFF\xFF\xFF"),
+		pat:  []byte("MThd\x00\x00\x00\x06"),
+		ct:   "audio/midi",
+	},
+	maskedSig{
+		mask: []byte("\xFF\xFF\xFF\xFF\x00\x00\x00\x00\xFF\xFF\xFF\xFF"),
+		pat:  []byte("RIFF\x00\x00\x00\x00AVI "),
+		ct:   "video/avi",
+	},
+	maskedSig{
+		mask: []byte("\xFF\xFF\xFF\xFF\x00\x00\x00\x00\xFF\xFF\xFF\xFF"),
+		pat:  []byte("RIFF\x00\x00\x00\x00WAVE"),
+		ct:   "audio/wave",
+	},
+	mp4Sig{},
+	exactSig{[]byte("\x1A\x45\xDF\xA3"), "video/webm"},
+
+	// Font types.
+	maskedSig{
+		// 34 NULL bytes followed by "LP".
+		pat: []byte("\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00LP"),
+		// 34 NULL bytes followed by \xFF\xFF.
+		mask: []byte("\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\xFF\xFF"),
+		ct:   "application/vnd.ms-fontobject",
+	},
+	exactSig{[]byte("\x00\x01\x00\x00"), "font/ttf"},
+	exactSig{[]byte("OTTO"), "font/otf"},
+	exactSig{[]byte("ttcf"), "font/collection"},
+	exactSig{[]byte("wOFF"), "font/woff"},
+	exactSig{[]byte("wOF2"), "font/woff2"},
+
+	// Archive types.
+	exactSig{[]byte("\x1F\x8B\x08"), "application/x-gzip"},
+	exactSig{[]byte("\x50\x4B\x03\x04"), "application/zip"},
+	// RAR's signatures are incorrectly defined by the MIME spec, per
+	// https://github.com/whatwg/mimesniff/issues/63; like net/http, we use
+	// RAR Labs' own definition instead: https://www.rarlab.com/technote.htm#rarsign.
+	exactSig{[]byte("Rar!\x1A\x07\x00"), "application/x-rar-compressed"},
+	exactSig{[]byte("Rar!\x1A\x07\x01\x00"), "application/x-rar-compressed"},
+
+	matcherSig{matchers.Wasm, "application/wasm"},
+
+	textSig{}, // must stay last: it matches almost any byte sequence.
+}
@@ -0,0 +1,72 @@
+package mimetype
+
+import "testing"
+
+func TestDetectContentType(t *testing.T) {
+	cases := []struct {
+		name string
+		in   []byte
+		want string
+	}{
+		{"empty", []byte{}, "text/plain; charset=utf-8"},
+		{"html doctype", []byte("<!DOCTYPE HTML><html></html>"), "text/html; charset=utf-8"},
+		{"html leading whitespace", []byte("\r\n\t <HTML><head></head></html>"), "text/html; charset=utf-8"},
+		{"html anchor tag", []byte("<A href=\"x\">link</A>"), "text/html; charset=utf-8"},
+		{"html tag needs terminator", []byte("<ABBR>not a recognized tag"), "text/plain; charset=utf-8"},
+		{"xml", []byte("<?xml version=\"1.0\"?><root/>"), "text/xml; charset=utf-8"},
+		{"pdf", []byte("%PDF-1.7 rest of file"), "application/pdf"},
+		{"gif87", []byte("GIF87a"), "image/gif"},
+		{"gif89", []byte("GIF89a"), "image/gif"},
+		{"png", []byte("\x89PNG\r\n\x1A\n"), "image/png"},
+		{"jpeg", []byte("\xFF\xD8\xFF\xE0"), "image/jpeg"},
+		{"webp", append([]byte("RIFF\x00\x00\x00\x00WEBPVP"), make([]byte, 8)...), "image/webp"},
+		{"wasm", []byte("\x00\x61\x73\x6D\x01\x00\x00\x00"), "application/wasm"},
+		{"utf-16be bom", []byte("\xFE\xFF\x00\x41\x00\x42"), "text/plain; charset=utf-16be"},
+		{"utf-16le bom", []byte("\xFF\xFE\x41\x00\x42\x00"), "text/plain; charset=utf-16le"},
+		{"utf-8 bom", []byte("\xEF\xBB\xBFhello"), "text/plain; charset=utf-8"},
+		{"mp3 id3", []byte("ID3\x03\x00\x00\x00\x00\x00\x00"), "audio/mpeg"},
+		{"aiff", append([]byte("FORM\x00\x00\x00\x00AIFF"), make([]byte, 4)...), "audio/aiff"},
+		{"avi", append([]byte("RIFF\x00\x00\x00\x00AVI "), make([]byte, 4)...), "video/avi"},
+		{"rar v4", []byte("Rar!\x1A\x07\x00"), "application/x-rar-compressed"},
+		{"rar v5", []byte("Rar!\x1A\x07\x01\x00"), "application/x-rar-compressed"},
+		{"eot", append(make([]byte, 34), 'L', 'P'), "application/vnd.ms-fontobject"},
+		{"elf falls back to octet-stream", append([]byte{0x7F, 'E', 'L', 'F'}, make([]byte, 16)...), "application/octet-stream"},
+		{"exe falls back to octet-stream", append([]byte{0x4D, 0x5A}, make([]byte, 16)...), "application/octet-stream"},
+		{"binary falls back to octet-stream", []byte{0x00, 0x01, 0x02, 0x03}, "application/octet-stream"},
+		{"text", []byte("just some plain text"), "text/plain; charset=utf-8"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			if got := DetectContentType(c.in); got != c.want {
+				t.Errorf("DetectContentType(%q) = %q, want %q", c.in, got, c.want)
+			}
+		})
+	}
+}
+
+func TestDetectContentTypeMp4(t *testing.T) {
+	// A minimal ftyp box: size(20) + "ftyp" + major brand "isom" + minor
+	// version + one compatible brand "mp41".
+	mp4 := []byte{0x00, 0x00, 0x00, 0x14}
+	mp4 = append(mp4, "ftypisom"...)
+	mp4 = append(mp4, 0x00, 0x00, 0x00, 0x00)
+	mp4 = append(mp4, "mp41"...)
+
+	if got := DetectContentType(mp4); got != "video/mp4" {
+		t.Errorf("DetectContentType(mp4 ftyp box) = %q, want video/mp4", got)
+	}
+}
+
+func TestDetectContentTypeTruncatesAt512Bytes(t *testing.T) {
+	in := make([]byte, 1024)
+	for i := range in {
+		in[i] = 'a'
+	}
+	// A binary byte past offset 512 would rule out text/plain if sniffed.
+	in[600] = 0x00
+
+	if got := DetectContentType(in); got != "text/plain; charset=utf-8" {
+		t.Errorf("DetectContentType should ignore bytes past offset 512, got %q", got)
+	}
+}